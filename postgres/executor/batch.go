@@ -0,0 +1,203 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// BatchExecutor queues statements against a pgx.Batch instead of executing them
+// immediately. It implements Executor so repository code can be written once and
+// run either directly or as part of a batch, depending on what Manager.GetExecutor
+// resolves for the current context. Results are only available once the batch is
+// sent, so Exec/Query/QueryRow merely queue and discard their result here; use
+// QueueSelect/QueueGet/QueueExec to have FlushBatch scan results for you.
+type BatchExecutor struct {
+	Batch   *pgx.Batch
+	parent  Executor
+	maxSize int
+	queue   []queuedStatement
+}
+
+// queuedStatement consumes the BatchResults entry produced for one queued
+// statement, in the same order the statement was added to Batch.
+type queuedStatement struct {
+	consume func(pgx.BatchResults) error
+}
+
+// BatchOption configures a BatchExecutor created by Manager.NewBatch.
+type BatchOption func(*BatchExecutor)
+
+// WithMaxBatchSize auto-flushes the batch as soon as it holds n queued statements.
+func WithMaxBatchSize(n int) BatchOption {
+	return func(b *BatchExecutor) { b.maxSize = n }
+}
+
+// Len reports how many statements are currently queued.
+func (b *BatchExecutor) Len() int { return b.Batch.Len() }
+
+func (b *BatchExecutor) push(ctx context.Context, stmt queuedStatement) error {
+	b.queue = append(b.queue, stmt)
+	if b.maxSize > 0 && b.Batch.Len() >= b.maxSize {
+		_, err := b.flush(ctx)
+		return err
+	}
+	return nil
+}
+
+// Exec queues sql and discards its CommandTag once the batch is flushed.
+// Prefer QueueExec when the CommandTag is needed.
+func (b *BatchExecutor) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	b.Batch.Queue(sql, arguments...)
+	err := b.push(ctx, queuedStatement{
+		consume: func(br pgx.BatchResults) error {
+			_, err := br.Exec()
+			return err
+		},
+	})
+	return pgconn.CommandTag{}, err
+}
+
+// Query queues sql for execution. Because rows can only be read once the batch is
+// sent, prefer QueueSelect/QueueGet, which scan into a destination automatically
+// when the batch is flushed; the returned pgx.Rows is always nil here.
+func (b *BatchExecutor) Query(ctx context.Context, sql string, optionsAndArgs ...any) (pgx.Rows, error) {
+	b.Batch.Queue(sql, optionsAndArgs...)
+	err := b.push(ctx, queuedStatement{
+		consume: func(br pgx.BatchResults) error {
+			rows, err := br.Query()
+			if err != nil {
+				return err
+			}
+			rows.Close()
+			return rows.Err()
+		},
+	})
+	return nil, err
+}
+
+// QueryRow queues sql for execution; see the Query caveat about reading results
+// before the batch is flushed. The returned pgx.Row is always nil here.
+func (b *BatchExecutor) QueryRow(ctx context.Context, sql string, optionsAndArgs ...any) pgx.Row {
+	b.Batch.Queue(sql, optionsAndArgs...)
+	_ = b.push(ctx, queuedStatement{
+		consume: func(br pgx.BatchResults) error {
+			rows, err := br.Query()
+			if err != nil {
+				return err
+			}
+			rows.Close()
+			return rows.Err()
+		},
+	})
+	return nil
+}
+
+// SendBatch is not supported on BatchExecutor: a batch cannot queue another batch.
+// It exists only to satisfy Executor.
+func (b *BatchExecutor) SendBatch(context.Context, *pgx.Batch) pgx.BatchResults {
+	panic("executor: nested SendBatch is not supported on BatchExecutor")
+}
+
+// CopyFrom is not supported inside a batch: pgx has no wire-protocol equivalent for
+// queuing a COPY alongside simple/extended query statements.
+func (b *BatchExecutor) CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error) {
+	return 0, errors.New("executor: CopyFrom is not supported on BatchExecutor")
+}
+
+// QueueSelect queues sql and, once the batch is flushed, scans every row of this
+// statement's result into dst.
+func QueueSelect[T any](ctx context.Context, b *BatchExecutor, dst *[]T, sql string, args ...any) error {
+	b.Batch.Queue(sql, args...)
+	return b.push(ctx, queuedStatement{
+		consume: func(br pgx.BatchResults) error {
+			rows, err := br.Query()
+			if err != nil {
+				return err
+			}
+			return pgxscan.ScanAll(dst, rows)
+		},
+	})
+}
+
+// QueueGet queues sql and, once the batch is flushed, scans the single row of this
+// statement's result into dst. An empty result is reported as ErrNotFound.
+func QueueGet[T any](ctx context.Context, b *BatchExecutor, dst *T, sql string, args ...any) error {
+	b.Batch.Queue(sql, args...)
+	return b.push(ctx, queuedStatement{
+		consume: func(br pgx.BatchResults) error {
+			rows, err := br.Query()
+			if err != nil {
+				return err
+			}
+			if err = pgxscan.ScanOne(dst, rows); err != nil {
+				if pgxscan.NotFound(err) {
+					return ErrNotFound
+				}
+				return err
+			}
+			return nil
+		},
+	})
+}
+
+// QueueExec queues sql and, once the batch is flushed, stores its CommandTag in tag
+// when tag is non-nil.
+func QueueExec(ctx context.Context, b *BatchExecutor, tag *pgconn.CommandTag, sql string, args ...any) error {
+	b.Batch.Queue(sql, args...)
+	return b.push(ctx, queuedStatement{
+		consume: func(br pgx.BatchResults) error {
+			t, err := br.Exec()
+			if err != nil {
+				return err
+			}
+			if tag != nil {
+				*tag = t
+			}
+			return nil
+		},
+	})
+}
+
+// flush sends b.Batch via b.parent, scans each queued statement's result into its
+// destination in queue order, and resets b for reuse. It returns one error per
+// queued statement (a nil entry means that statement succeeded) alongside a single
+// combined error wrapping every failure, for callers that only care whether
+// flushing succeeded as a whole.
+func (b *BatchExecutor) flush(ctx context.Context) ([]error, error) {
+	if b.Batch.Len() == 0 {
+		return nil, nil
+	}
+
+	results := b.parent.SendBatch(ctx, b.Batch)
+	defer results.Close()
+
+	errs := make([]error, len(b.queue))
+	var combined error
+	for i, stmt := range b.queue {
+		if err := stmt.consume(results); err != nil {
+			errs[i] = err
+			combined = errors.Join(combined, fmt.Errorf("executor: batch statement %d: %w", i, err))
+		}
+	}
+
+	b.Batch = &pgx.Batch{}
+	b.queue = nil
+
+	return errs, combined
+}
+
+// FlushBatch sends the batch found in ctx and scans each queued statement's result
+// into its destination in queue order. See BatchExecutor.flush for the return
+// values' meaning.
+func (m *Manager) FlushBatch(ctx context.Context) ([]error, error) {
+	batch, ok := m.ExtractBatch(ctx)
+	if !ok {
+		return nil, errors.New("executor: no batch found in context")
+	}
+	return batch.flush(ctx)
+}
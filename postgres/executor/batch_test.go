@@ -0,0 +1,195 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/D1sordxr/packages/postgres/pgerr"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/tracelog"
+)
+
+// fakeBatchResults replays canned Exec responses in call order, mimicking how
+// pgx.BatchResults hands back one result per queued statement. Only Exec is
+// exercised by these tests; Query/QueryRow are not needed since they require a
+// live connection to produce pgx.Rows.
+type fakeBatchResults struct {
+	execTags []pgconn.CommandTag
+	execErrs []error
+	next     int
+	closed   bool
+}
+
+func (f *fakeBatchResults) Exec() (pgconn.CommandTag, error) {
+	i := f.next
+	f.next++
+
+	var tag pgconn.CommandTag
+	if i < len(f.execTags) {
+		tag = f.execTags[i]
+	}
+
+	var err error
+	if i < len(f.execErrs) {
+		err = f.execErrs[i]
+	}
+
+	return tag, err
+}
+
+func (f *fakeBatchResults) Query() (pgx.Rows, error) { panic("not implemented") }
+func (f *fakeBatchResults) QueryRow() pgx.Row        { panic("not implemented") }
+
+func (f *fakeBatchResults) Close() error {
+	f.closed = true
+	return nil
+}
+
+func TestBatchExecutor_Flush_ScansResultsInQueueOrder(t *testing.T) {
+	results := &fakeBatchResults{
+		execTags: []pgconn.CommandTag{
+			pgconn.NewCommandTag("INSERT 0 1"),
+			pgconn.NewCommandTag("INSERT 0 1"),
+			pgconn.NewCommandTag("UPDATE 1"),
+		},
+	}
+	parent := &fakeBatchParent{results: results}
+
+	b := &BatchExecutor{Batch: &pgx.Batch{}, parent: parent}
+
+	var tag1, tag2, tag3 pgconn.CommandTag
+	if err := QueueExec(context.Background(), b, &tag1, "INSERT INTO a VALUES (1)"); err != nil {
+		t.Fatalf("QueueExec 1: %v", err)
+	}
+	if err := QueueExec(context.Background(), b, &tag2, "INSERT INTO a VALUES (2)"); err != nil {
+		t.Fatalf("QueueExec 2: %v", err)
+	}
+	if err := QueueExec(context.Background(), b, &tag3, "UPDATE a SET x = 1"); err != nil {
+		t.Fatalf("QueueExec 3: %v", err)
+	}
+
+	errs, err := b.flush(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected combined error: %v", err)
+	}
+	if len(errs) != 3 {
+		t.Fatalf("len(errs) = %d, want 3", len(errs))
+	}
+	for i, e := range errs {
+		if e != nil {
+			t.Fatalf("errs[%d] = %v, want nil", i, e)
+		}
+	}
+
+	if tag1.String() != "INSERT 0 1" || tag2.String() != "INSERT 0 1" || tag3.String() != "UPDATE 1" {
+		t.Fatalf("tags scanned out of order: %q %q %q", tag1, tag2, tag3)
+	}
+	if !results.closed {
+		t.Fatal("expected flush to Close the batch results")
+	}
+	if b.Len() != 0 {
+		t.Fatalf("batch should be reset after flush, len = %d", b.Len())
+	}
+}
+
+func TestBatchExecutor_Flush_CombinesPerStatementErrors(t *testing.T) {
+	wantErr := errors.New("constraint violated")
+	results := &fakeBatchResults{
+		execTags: []pgconn.CommandTag{{}, {}},
+		execErrs: []error{nil, wantErr},
+	}
+	parent := &fakeBatchParent{results: results}
+
+	b := &BatchExecutor{Batch: &pgx.Batch{}, parent: parent}
+	_ = QueueExec(context.Background(), b, nil, "INSERT INTO a VALUES (1)")
+	_ = QueueExec(context.Background(), b, nil, "INSERT INTO a VALUES (2)")
+
+	errs, combined := b.flush(context.Background())
+	if errs[0] != nil {
+		t.Fatalf("errs[0] = %v, want nil", errs[0])
+	}
+	if !errors.Is(errs[1], wantErr) {
+		t.Fatalf("errs[1] = %v, want to wrap %v", errs[1], wantErr)
+	}
+	if !errors.Is(combined, wantErr) {
+		t.Fatalf("combined = %v, want to wrap %v", combined, wantErr)
+	}
+}
+
+// TestManager_NewBatch_FlushIsTelemetredAndTranslated guards against the parent
+// executor a BatchExecutor flushes through bypassing the telemetry and pgerr
+// wrapping that GetExecutor and GetExecutorTranslated apply: NewBatch binds parent
+// via baseExecutor, which must run it through both WithTelemetry and WithPgErr.
+func TestManager_NewBatch_FlushIsTelemetredAndTranslated(t *testing.T) {
+	pgErr := &pgconn.PgError{Code: "23505", Message: "duplicate key"}
+	tx := &fakeTx{
+		sendBatchResults: &fakeBatchResults{
+			execTags: []pgconn.CommandTag{{}},
+			execErrs: []error{pgErr},
+		},
+	}
+
+	var logged []map[string]any
+	m := &Manager{telemetry: TelemetryConfig{
+		Logger: tracelog.LoggerFunc(func(_ context.Context, _ tracelog.LogLevel, _ string, data map[string]any) {
+			logged = append(logged, data)
+		}),
+	}}
+
+	ctx := m.InjectTx(context.Background(), tx)
+	b := m.NewBatch(ctx)
+
+	if err := QueueExec(ctx, b, nil, "INSERT INTO a VALUES (1)"); err != nil {
+		t.Fatalf("QueueExec: %v", err)
+	}
+
+	errs, _ := b.flush(ctx)
+	if len(errs) != 1 {
+		t.Fatalf("len(errs) = %d, want 1", len(errs))
+	}
+	if !pgerr.IsUniqueViolation(errs[0]) {
+		t.Fatalf("errs[0] = %v, want a translated unique violation", errs[0])
+	}
+
+	if len(logged) == 0 {
+		t.Fatal("expected flushing the batch to produce at least one telemetry log line")
+	}
+	found := false
+	for _, data := range logged {
+		if data["kind"] == "tx" {
+			found = true
+		}
+	}
+	if !found {
+		t.Fatalf("expected a log line for kind=tx, got %+v", logged)
+	}
+}
+
+func TestBatchExecutor_AutoFlushesAtMaxSize(t *testing.T) {
+	results := &fakeBatchResults{execTags: []pgconn.CommandTag{{}, {}}}
+	parent := &fakeBatchParent{results: results}
+
+	b := &BatchExecutor{Batch: &pgx.Batch{}, parent: parent, maxSize: 2}
+
+	if err := QueueExec(context.Background(), b, nil, "INSERT INTO a VALUES (1)"); err != nil {
+		t.Fatalf("QueueExec 1: %v", err)
+	}
+	if parent.sent != nil {
+		t.Fatal("batch should not flush before reaching maxSize")
+	}
+
+	if err := QueueExec(context.Background(), b, nil, "INSERT INTO a VALUES (2)"); err != nil {
+		t.Fatalf("QueueExec 2: %v", err)
+	}
+	if parent.sent == nil {
+		t.Fatal("expected batch to auto-flush once maxSize was reached")
+	}
+	if parent.sent.Len() != 2 {
+		t.Fatalf("flushed batch size = %d, want 2", parent.sent.Len())
+	}
+	if b.Len() != 0 {
+		t.Fatalf("batch should be empty after auto-flush, len = %d", b.Len())
+	}
+}
@@ -27,11 +27,25 @@ type (
 // It is used to manage transactions and batches in the context and delegate queries to the appropriate executor.
 type Manager struct {
 	*postgres.Pool
+	telemetry TelemetryConfig
+}
+
+// Option configures optional Manager behavior, such as tracing and metrics.
+type Option func(*Manager)
+
+// WithTelemetryConfig attaches tracing, slow-query logging and Prometheus metrics to
+// every executor GetExecutor hands out.
+func WithTelemetryConfig(config TelemetryConfig) Option {
+	return func(m *Manager) { m.telemetry = config }
 }
 
 // NewManager creates a new Manager instance with the given Postgres connection pool.
-func NewManager(pool *postgres.Pool) *Manager {
-	return &Manager{Pool: pool}
+func NewManager(pool *postgres.Pool, opts ...Option) *Manager {
+	m := &Manager{Pool: pool}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
 }
 
 // InjectTx stores a transaction in the context for later retrieval.
@@ -46,20 +60,62 @@ func (m *Manager) ExtractTx(ctx context.Context) (pgx.Tx, bool) {
 	return tx, ok
 }
 
-// NewBatch creates a new BatchExecutor for queueing batch queries.
-func (m *Manager) NewBatch() *BatchExecutor {
-	return &BatchExecutor{Batch: &pgx.Batch{}}
+// NewBatch creates a new BatchExecutor for queueing batch queries. The batch sends
+// itself, once flushed, through the transaction in ctx, if any, or else the pool
+// (see baseExecutor; a batch already in ctx is ignored), so statements queued
+// while a transaction is open are flushed on that same transaction's connection.
+func (m *Manager) NewBatch(ctx context.Context, opts ...BatchOption) *BatchExecutor {
+	b := &BatchExecutor{Batch: &pgx.Batch{}, parent: m.baseExecutor(ctx)}
+	for _, opt := range opts {
+		opt(b)
+	}
+	return b
+}
+
+// baseExecutor resolves the tx or pool executor for ctx, ignoring any batch
+// already present, wrapped with the same telemetry and pgerr translation
+// GetExecutor/GetExecutorTranslated apply elsewhere. It is used to bind a freshly
+// created BatchExecutor to the connection it should flush through, so the one real
+// network send a batch makes (SendBatch, in flush) is instrumented and its errors
+// translated just like every other executor path.
+func (m *Manager) baseExecutor(ctx context.Context) Executor {
+	kind := "pool"
+	base := Executor(&PoolExecutor{Pool: m.Pool})
+	if tx, ok := m.ExtractTx(ctx); ok {
+		kind = "tx"
+		base = tx
+	}
+	return WithPgErr(WithTelemetry(base, kind, m.telemetry))
+}
+
+// batchHolder is a mutable cell stored once per call chain under batchKey. Storing
+// a pointer to it (rather than the *BatchExecutor directly) lets InjectBatch update
+// the batch an ancestor context will see even when the batch is created on a
+// context derived further down the call chain (e.g. inside a RunInTransaction
+// callback), since every descendant context shares the same holder instance.
+type batchHolder struct {
+	batch *BatchExecutor
 }
 
-// InjectBatch stores a batch in the context for later retrieval.
+// InjectBatch stores batch in the context for later retrieval. If ctx already
+// carries a batch holder (seeded by RunInTransaction or a prior InjectBatch call),
+// it is updated in place so the batch also becomes visible to any ancestor context
+// that shares the same holder.
 func (m *Manager) InjectBatch(ctx context.Context, batch *BatchExecutor) context.Context {
-	return context.WithValue(ctx, batchKey{}, batch)
+	if holder, ok := ctx.Value(batchKey{}).(*batchHolder); ok {
+		holder.batch = batch
+		return ctx
+	}
+	return context.WithValue(ctx, batchKey{}, &batchHolder{batch: batch})
 }
 
-// ExtractBatch retrieves a batch from the context, if it exists.
+// ExtractBatch retrieves a batch from the context, if one has been injected.
 func (m *Manager) ExtractBatch(ctx context.Context) (*BatchExecutor, bool) {
-	batch, ok := ctx.Value(batchKey{}).(*BatchExecutor)
-	return batch, ok
+	holder, ok := ctx.Value(batchKey{}).(*batchHolder)
+	if !ok || holder.batch == nil {
+		return nil, false
+	}
+	return holder.batch, true
 }
 
 // GetExecutor returns the appropriate executor based on the context.
@@ -68,14 +124,14 @@ func (m *Manager) ExtractBatch(ctx context.Context) (*BatchExecutor, bool) {
 // Otherwise, it returns a PoolExecutor, which wraps the connection pool.
 func (m *Manager) GetExecutor(ctx context.Context) Executor {
 	if batch, ok := m.ExtractBatch(ctx); ok {
-		return batch
+		return WithTelemetry(batch, "batch", m.telemetry)
 	}
 
 	if tx, ok := m.ExtractTx(ctx); ok {
-		return tx
+		return WithTelemetry(tx, "tx", m.telemetry)
 	}
 
-	return &PoolExecutor{Pool: m.Pool}
+	return WithTelemetry(&PoolExecutor{Pool: m.Pool}, "pool", m.telemetry)
 }
 
 // GetPoolExecutor returns a PoolExecutor that wraps the connection pool.
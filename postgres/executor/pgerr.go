@@ -0,0 +1,78 @@
+package executor
+
+import (
+	"context"
+
+	"github.com/D1sordxr/packages/postgres/pgerr"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// pgErrExecutor wraps an Executor so every error it returns passes through
+// pgerr.Translate, letting callers branch on pgerr's typed sentinels regardless of
+// whether the underlying executor is a PoolExecutor, pgx.Tx, or BatchExecutor.
+type pgErrExecutor struct {
+	Executor
+}
+
+// Unwrap returns the Executor this pgErrExecutor wraps, so callers that need to see
+// past error translation (e.g. isBatchExecutor) can look through it.
+func (e *pgErrExecutor) Unwrap() Executor { return e.Executor }
+
+// WithPgErr wraps exec so its errors are translated via pgerr.Translate.
+func WithPgErr(exec Executor) Executor {
+	return &pgErrExecutor{Executor: exec}
+}
+
+func (e *pgErrExecutor) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	tag, err := e.Executor.Exec(ctx, sql, arguments...)
+	return tag, pgerr.Translate(err)
+}
+
+func (e *pgErrExecutor) Query(ctx context.Context, sql string, optionsAndArgs ...any) (pgx.Rows, error) {
+	rows, err := e.Executor.Query(ctx, sql, optionsAndArgs...)
+	return rows, pgerr.Translate(err)
+}
+
+func (e *pgErrExecutor) QueryRow(ctx context.Context, sql string, optionsAndArgs ...any) pgx.Row {
+	return &pgErrRow{Row: e.Executor.QueryRow(ctx, sql, optionsAndArgs...)}
+}
+
+func (e *pgErrExecutor) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	return &pgErrBatchResults{BatchResults: e.Executor.SendBatch(ctx, b)}
+}
+
+func (e *pgErrExecutor) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	n, err := e.Executor.CopyFrom(ctx, tableName, columnNames, rowSrc)
+	return n, pgerr.Translate(err)
+}
+
+// pgErrRow translates the error a wrapped pgx.Row returns from Scan.
+type pgErrRow struct{ pgx.Row }
+
+func (r *pgErrRow) Scan(dest ...any) error {
+	return pgerr.Translate(r.Row.Scan(dest...))
+}
+
+// pgErrBatchResults translates errors surfaced while reading queued batch results.
+type pgErrBatchResults struct{ pgx.BatchResults }
+
+func (b *pgErrBatchResults) Exec() (pgconn.CommandTag, error) {
+	tag, err := b.BatchResults.Exec()
+	return tag, pgerr.Translate(err)
+}
+
+func (b *pgErrBatchResults) Query() (pgx.Rows, error) {
+	rows, err := b.BatchResults.Query()
+	return rows, pgerr.Translate(err)
+}
+
+func (b *pgErrBatchResults) QueryRow() pgx.Row {
+	return &pgErrRow{Row: b.BatchResults.QueryRow()}
+}
+
+// GetExecutorTranslated returns the executor GetExecutor resolves for ctx, wrapped
+// so any *pgconn.PgError it returns is translated into the typed errors in pgerr.
+func (m *Manager) GetExecutorTranslated(ctx context.Context) Executor {
+	return WithPgErr(m.GetExecutor(ctx))
+}
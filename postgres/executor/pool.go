@@ -0,0 +1,36 @@
+package executor
+
+import (
+	"context"
+
+	"github.com/D1sordxr/packages/postgres"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// PoolExecutor adapts *postgres.Pool to the Executor interface, so code written
+// against Executor runs directly against the connection pool whenever GetExecutor
+// finds no transaction or batch in the current context.
+type PoolExecutor struct {
+	Pool *postgres.Pool
+}
+
+func (p *PoolExecutor) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	return p.Pool.Exec(ctx, sql, arguments...)
+}
+
+func (p *PoolExecutor) Query(ctx context.Context, sql string, optionsAndArgs ...any) (pgx.Rows, error) {
+	return p.Pool.Query(ctx, sql, optionsAndArgs...)
+}
+
+func (p *PoolExecutor) QueryRow(ctx context.Context, sql string, optionsAndArgs ...any) pgx.Row {
+	return p.Pool.QueryRow(ctx, sql, optionsAndArgs...)
+}
+
+func (p *PoolExecutor) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	return p.Pool.SendBatch(ctx, b)
+}
+
+func (p *PoolExecutor) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	return p.Pool.CopyFrom(ctx, tableName, columnNames, rowSrc)
+}
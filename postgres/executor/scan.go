@@ -0,0 +1,84 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/georgysavva/scany/v2/pgxscan"
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrNotFound is returned by Get (and by the batch Queue helpers that scan a
+// single row) when the query executed successfully but produced no rows. It wraps
+// pgx.ErrNoRows so pgerr.IsEmptyResponse also recognizes it as an empty response.
+var ErrNotFound = fmt.Errorf("executor: not found: %w", pgx.ErrNoRows)
+
+// ErrBatchUnsupported is returned by Select and Get when given a *BatchExecutor: a
+// batch only produces rows once it is flushed, so there is nothing to scan yet.
+// Use QueueSelect/QueueGet to scan into a destination once FlushBatch runs.
+var ErrBatchUnsupported = errors.New("executor: Select/Get do not support a batch executor; use QueueSelect/QueueGet instead")
+
+// queryer is the minimal subset of Executor that Select and Get need. PoolExecutor
+// and pgx.Tx satisfy it; a *BatchExecutor is rejected explicitly since it cannot
+// return rows until the batch is flushed.
+type queryer interface {
+	Query(ctx context.Context, sql string, optionsAndArgs ...any) (pgx.Rows, error)
+}
+
+// unwrapExecutor is implemented by decorators such as tracingExecutor and
+// pgErrExecutor so isBatchExecutor can see through them to the executor they wrap.
+type unwrapExecutor interface {
+	Unwrap() Executor
+}
+
+// isBatchExecutor reports whether exec is a *BatchExecutor, looking through any
+// chain of telemetry/pgerr decorators GetExecutor/GetExecutorTranslated may have
+// applied on top of it.
+func isBatchExecutor(exec any) bool {
+	for {
+		if _, ok := exec.(*BatchExecutor); ok {
+			return true
+		}
+		u, ok := exec.(unwrapExecutor)
+		if !ok {
+			return false
+		}
+		exec = u.Unwrap()
+	}
+}
+
+// Select runs sql against exec and scans every resulting row into dst using scany.
+func Select[T any](ctx context.Context, exec queryer, dst *[]T, sql string, args ...any) error {
+	if isBatchExecutor(exec) {
+		return ErrBatchUnsupported
+	}
+
+	rows, err := exec.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	return pgxscan.ScanAll(dst, rows)
+}
+
+// Get runs sql against exec and scans a single resulting row into dst using scany.
+// A query that succeeds but returns no rows is reported as ErrNotFound.
+func Get[T any](ctx context.Context, exec queryer, dst *T, sql string, args ...any) error {
+	if isBatchExecutor(exec) {
+		return ErrBatchUnsupported
+	}
+
+	rows, err := exec.Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+
+	if err = pgxscan.ScanOne(dst, rows); err != nil {
+		if pgxscan.NotFound(err) {
+			return ErrNotFound
+		}
+		return err
+	}
+
+	return nil
+}
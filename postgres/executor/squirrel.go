@@ -0,0 +1,64 @@
+package executor
+
+import (
+	"context"
+	"fmt"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// ExecSq builds sql from b and executes it via the executor GetExecutor resolves
+// for ctx, so the call transparently joins an outer transaction or batch.
+func (m *Manager) ExecSq(ctx context.Context, b sq.Sqlizer) (pgconn.CommandTag, error) {
+	query, args, err := b.ToSql()
+	if err != nil {
+		return pgconn.CommandTag{}, fmt.Errorf("executor: build sql: %w", err)
+	}
+	return m.GetExecutor(ctx).Exec(ctx, query, args...)
+}
+
+// QuerySq builds sql from b and runs it via Query on the executor GetExecutor
+// resolves for ctx.
+func (m *Manager) QuerySq(ctx context.Context, b sq.Sqlizer) (pgx.Rows, error) {
+	query, args, err := b.ToSql()
+	if err != nil {
+		return nil, fmt.Errorf("executor: build sql: %w", err)
+	}
+	return m.GetExecutor(ctx).Query(ctx, query, args...)
+}
+
+// QueryRowSq builds sql from b and runs it via QueryRow. A build error is deferred
+// to the first Scan call, matching how pgx.Row already reports query errors.
+func (m *Manager) QueryRowSq(ctx context.Context, b sq.Sqlizer) pgx.Row {
+	query, args, err := b.ToSql()
+	if err != nil {
+		return errRow{err}
+	}
+	return m.GetExecutor(ctx).QueryRow(ctx, query, args...)
+}
+
+// SelectSq builds sql from b and scans every resulting row into dst.
+func SelectSq[T any](ctx context.Context, m *Manager, dst *[]T, b sq.Sqlizer) error {
+	query, args, err := b.ToSql()
+	if err != nil {
+		return fmt.Errorf("executor: build sql: %w", err)
+	}
+	return Select(ctx, m.GetExecutor(ctx), dst, query, args...)
+}
+
+// GetSq builds sql from b and scans a single resulting row into dst.
+func GetSq[T any](ctx context.Context, m *Manager, dst *T, b sq.Sqlizer) error {
+	query, args, err := b.ToSql()
+	if err != nil {
+		return fmt.Errorf("executor: build sql: %w", err)
+	}
+	return Get(ctx, m.GetExecutor(ctx), dst, query, args...)
+}
+
+// errRow is a pgx.Row whose Scan always returns err, used to surface a squirrel
+// build failure through QueryRowSq without changing its return type.
+type errRow struct{ err error }
+
+func (r errRow) Scan(...any) error { return r.err }
@@ -0,0 +1,270 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/tracelog"
+	"github.com/prometheus/client_golang/prometheus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// noRowCount marks a call for which a row count is not available (e.g. Query,
+// whose rows are read lazily by the caller) so observe omits the attribute instead
+// of reporting a misleading zero.
+const noRowCount = -1
+
+// TelemetryConfig configures tracing, slow-query logging and metrics for a Manager.
+// The zero value disables telemetry entirely: WithTelemetry then returns exec
+// unchanged, adding no overhead.
+type TelemetryConfig struct {
+	TracerProvider     trace.TracerProvider
+	Logger             tracelog.Logger
+	SlowQueryThreshold time.Duration
+	Metrics            *Metrics
+
+	// RedactSQL, if set, is applied to SQL text before it is attached to spans or
+	// log lines. Use it to strip literals or hash the statement when query text
+	// must not reach tracing/logging backends verbatim.
+	RedactSQL func(sql string) string
+}
+
+func (c TelemetryConfig) tracer() trace.Tracer {
+	if c.TracerProvider == nil {
+		return nil
+	}
+	return c.TracerProvider.Tracer("github.com/D1sordxr/packages/postgres/executor")
+}
+
+func (c TelemetryConfig) redact(sql string) string {
+	if c.RedactSQL == nil {
+		return sql
+	}
+	return c.RedactSQL(sql)
+}
+
+// Metrics holds the Prometheus collectors exposed for query execution.
+type Metrics struct {
+	QueryDuration *prometheus.HistogramVec
+	QueryErrors   *prometheus.CounterVec
+	BatchSize     prometheus.Histogram
+}
+
+// NewMetrics builds the default Metrics collectors and registers them against reg.
+func NewMetrics(reg prometheus.Registerer) *Metrics {
+	m := &Metrics{
+		QueryDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "postgres",
+			Subsystem: "executor",
+			Name:      "query_duration_seconds",
+			Help:      "Duration of executor queries by executor kind and operation.",
+		}, []string{"executor", "operation"}),
+		QueryErrors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "postgres",
+			Subsystem: "executor",
+			Name:      "query_errors_total",
+			Help:      "Count of executor query errors by executor kind, operation and SQLSTATE.",
+		}, []string{"executor", "operation", "sqlstate"}),
+		BatchSize: prometheus.NewHistogram(prometheus.HistogramOpts{
+			Namespace: "postgres",
+			Subsystem: "executor",
+			Name:      "batch_size",
+			Help:      "Number of statements queued per SendBatch call.",
+		}),
+	}
+	reg.MustRegister(m.QueryDuration, m.QueryErrors, m.BatchSize)
+	return m
+}
+
+// tracingExecutor wraps an Executor with spans, slow-query logging and metrics.
+type tracingExecutor struct {
+	Executor
+	kind   string
+	config TelemetryConfig
+}
+
+// Unwrap returns the Executor this tracingExecutor wraps, so callers that need to
+// see past telemetry instrumentation (e.g. isBatchExecutor) can look through it.
+func (e *tracingExecutor) Unwrap() Executor { return e.Executor }
+
+// WithTelemetry wraps exec so every call produces a span (when a TracerProvider is
+// configured), a log line (when a Logger is configured, escalated to warn once
+// SlowQueryThreshold is exceeded), and Prometheus observations (when Metrics is
+// set). kind identifies the underlying executor ("pool", "tx" or "batch") and is
+// attached as a span attribute and metric label. If config is entirely unset, exec
+// is returned unchanged.
+func WithTelemetry(exec Executor, kind string, config TelemetryConfig) Executor {
+	if config.TracerProvider == nil && config.Logger == nil && config.Metrics == nil {
+		return exec
+	}
+	return &tracingExecutor{Executor: exec, kind: kind, config: config}
+}
+
+// record logs and updates metrics for a completed call. It does not open a span,
+// so callers that manage their own span tree (SendBatch) can still get logging and
+// metrics without a duplicate span.
+func (e *tracingExecutor) record(ctx context.Context, operation, sql string, duration time.Duration, rowCount int64, err error) {
+	sql = e.config.redact(sql)
+
+	if e.config.Logger != nil {
+		level := tracelog.LogLevelInfo
+		switch {
+		case err != nil:
+			level = tracelog.LogLevelError
+		case e.config.SlowQueryThreshold > 0 && duration >= e.config.SlowQueryThreshold:
+			level = tracelog.LogLevelWarn
+		}
+		fields := map[string]any{
+			"operation": operation,
+			"kind":      e.kind,
+			"sql":       sql,
+			"duration":  duration,
+			"err":       err,
+		}
+		if rowCount != noRowCount {
+			fields["rows"] = rowCount
+		}
+		e.config.Logger.Log(ctx, level, "executor query", fields)
+	}
+
+	if e.config.Metrics != nil {
+		e.config.Metrics.QueryDuration.WithLabelValues(e.kind, operation).Observe(duration.Seconds())
+		if err != nil {
+			e.config.Metrics.QueryErrors.WithLabelValues(e.kind, operation, sqlState(err)).Inc()
+		}
+	}
+}
+
+// observe is record plus a span covering the call, for the simple single-statement
+// operations (Exec/Query/QueryRow/CopyFrom). SendBatch manages its own span tree
+// and calls record directly instead, to avoid nesting a second span under it.
+func (e *tracingExecutor) observe(ctx context.Context, operation, sql string, start time.Time, rowCount int64, err error) {
+	duration := time.Since(start)
+	redacted := e.config.redact(sql)
+
+	if tracer := e.config.tracer(); tracer != nil {
+		attrs := []attribute.KeyValue{
+			attribute.String("db.system", "postgresql"),
+			attribute.String("db.executor.kind", e.kind),
+			attribute.String("db.statement", redacted),
+		}
+		if rowCount != noRowCount {
+			attrs = append(attrs, attribute.Int64("db.rows_affected", rowCount))
+		}
+
+		_, span := tracer.Start(ctx, "postgres.executor."+operation, trace.WithAttributes(attrs...), trace.WithTimestamp(start))
+		if err != nil {
+			span.SetStatus(codes.Error, err.Error())
+			span.RecordError(err)
+		}
+		span.End()
+	}
+
+	e.record(ctx, operation, sql, duration, rowCount, err)
+}
+
+func (e *tracingExecutor) Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error) {
+	start := time.Now()
+	tag, err := e.Executor.Exec(ctx, sql, arguments...)
+
+	rowCount := int64(noRowCount)
+	if err == nil {
+		rowCount = tag.RowsAffected()
+	}
+	e.observe(ctx, "exec", sql, start, rowCount, err)
+
+	return tag, err
+}
+
+func (e *tracingExecutor) Query(ctx context.Context, sql string, optionsAndArgs ...any) (pgx.Rows, error) {
+	start := time.Now()
+	rows, err := e.Executor.Query(ctx, sql, optionsAndArgs...)
+	e.observe(ctx, "query", sql, start, noRowCount, err)
+	return rows, err
+}
+
+// QueryRow defers its error attribution to Scan, since pgx.Row.Scan is what
+// actually executes the query and returns its error.
+func (e *tracingExecutor) QueryRow(ctx context.Context, sql string, optionsAndArgs ...any) pgx.Row {
+	start := time.Now()
+	row := e.Executor.QueryRow(ctx, sql, optionsAndArgs...)
+	return &tracingRow{
+		Row: row,
+		onScan: func(err error) {
+			e.observe(ctx, "query_row", sql, start, noRowCount, err)
+		},
+	}
+}
+
+// tracingRow wraps a pgx.Row so the telemetry for QueryRow is recorded once the
+// caller actually Scans it, carrying the real success/error outcome.
+type tracingRow struct {
+	pgx.Row
+	onScan func(err error)
+}
+
+func (r *tracingRow) Scan(dest ...any) error {
+	err := r.Row.Scan(dest...)
+	r.onScan(err)
+	return err
+}
+
+func (e *tracingExecutor) SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+	start := time.Now()
+	size := b.Len()
+
+	var span trace.Span
+	if tracer := e.config.tracer(); tracer != nil {
+		ctx, span = tracer.Start(ctx, "postgres.executor.send_batch", trace.WithAttributes(
+			attribute.String("db.executor.kind", e.kind),
+			attribute.Int("db.batch.size", size),
+		))
+		for i := 0; i < size; i++ {
+			_, child := tracer.Start(ctx, "postgres.executor.send_batch.statement")
+			child.End()
+		}
+	}
+
+	if e.config.Metrics != nil {
+		e.config.Metrics.BatchSize.Observe(float64(size))
+	}
+
+	results := e.Executor.SendBatch(ctx, b)
+
+	if span != nil {
+		span.End()
+	}
+	// record only: the span tree above already covers this call, so calling
+	// observe here would start a second, redundant "send_batch" span.
+	e.record(ctx, "send_batch", "", time.Since(start), int64(size), nil)
+
+	return results
+}
+
+func (e *tracingExecutor) CopyFrom(ctx context.Context, tableName pgx.Identifier, columnNames []string, rowSrc pgx.CopyFromSource) (int64, error) {
+	start := time.Now()
+	n, err := e.Executor.CopyFrom(ctx, tableName, columnNames, rowSrc)
+
+	rowCount := int64(noRowCount)
+	if err == nil {
+		rowCount = n
+	}
+	e.observe(ctx, "copy_from", tableName.Sanitize(), start, rowCount, err)
+
+	return n, err
+}
+
+// sqlState extracts the SQLSTATE code from err, or "" when err does not wrap a
+// *pgconn.PgError.
+func sqlState(err error) string {
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) {
+		return pgErr.Code
+	}
+	return ""
+}
@@ -0,0 +1,118 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// ErrNoTransactionCtx is returned by RunInSubTransaction when ctx does not carry an
+// outer transaction to open a savepoint against.
+var ErrNoTransactionCtx = errors.New("executor: no transaction found in context")
+
+// defaultTxOptions are used by RunInTransaction whenever the caller passes a
+// zero-value pgx.TxOptions.
+var defaultTxOptions = pgx.TxOptions{IsoLevel: pgx.ReadCommitted}
+
+// RunInTransaction begins a transaction with opts (falling back to defaultTxOptions
+// when opts is the zero value), injects it into ctx via InjectTx, and runs fn with
+// the resulting context. The transaction is committed if fn returns nil and rolled
+// back otherwise; a panic inside fn rolls back and is re-panicked after cleanup.
+func (m *Manager) RunInTransaction(ctx context.Context, opts pgx.TxOptions, fn func(ctx context.Context) error) error {
+	if opts == (pgx.TxOptions{}) {
+		opts = defaultTxOptions
+	}
+
+	tx, err := m.Pool.BeginTx(ctx, opts)
+	if err != nil {
+		return fmt.Errorf("executor: begin transaction: %w", err)
+	}
+
+	return m.runInTx(ctx, tx, fn)
+}
+
+// runInTx holds the commit/rollback/batch-flush logic shared by RunInTransaction
+// for every already-started tx. Split out so it can be exercised against a fake
+// pgx.Tx in tests without a real *postgres.Pool.
+func (m *Manager) runInTx(ctx context.Context, tx pgx.Tx, fn func(ctx context.Context) error) (err error) {
+	txCtx := m.InjectTx(ctx, tx)
+
+	// Seed a batch holder up front, unless ctx already carries one (the caller
+	// pre-injected a batch before calling RunInTransaction). This way, if fn calls
+	// m.NewBatch(txCtx) and m.InjectBatch on a context derived from txCtx, that
+	// batch lands in the same holder this function's defer reads from below, even
+	// though the derived context never escapes fn.
+	if _, ok := m.ExtractBatch(txCtx); !ok {
+		txCtx = context.WithValue(txCtx, batchKey{}, &batchHolder{})
+	}
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = tx.Rollback(ctx)
+			panic(p)
+		}
+
+		if err != nil {
+			if rbErr := tx.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				err = fmt.Errorf("executor: rollback transaction: %w (original error: %s)", rbErr, err)
+			}
+			return
+		}
+
+		if batch, ok := m.ExtractBatch(txCtx); ok {
+			if _, flushErr := batch.flush(ctx); flushErr != nil {
+				_ = tx.Rollback(ctx)
+				err = fmt.Errorf("executor: flush batch before commit: %w", flushErr)
+				return
+			}
+		}
+
+		if cErr := tx.Commit(ctx); cErr != nil {
+			err = fmt.Errorf("executor: commit transaction: %w", cErr)
+		}
+	}()
+
+	err = fn(txCtx)
+	return err
+}
+
+// RunInSubTransaction runs fn inside a SAVEPOINT nested within the transaction
+// already present in ctx, so repository code can be composed without knowing
+// whether it is already running inside a transaction. If ctx carries no outer
+// transaction, it returns ErrNoTransactionCtx.
+func (m *Manager) RunInSubTransaction(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	outerTx, ok := m.ExtractTx(ctx)
+	if !ok {
+		return ErrNoTransactionCtx
+	}
+
+	savepoint, err := outerTx.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("executor: begin savepoint: %w", err)
+	}
+
+	spCtx := m.InjectTx(ctx, savepoint)
+
+	defer func() {
+		if p := recover(); p != nil {
+			_ = savepoint.Rollback(ctx)
+			panic(p)
+		}
+
+		if err != nil {
+			if rbErr := savepoint.Rollback(ctx); rbErr != nil && !errors.Is(rbErr, pgx.ErrTxClosed) {
+				err = fmt.Errorf("executor: rollback to savepoint: %w (original error: %s)", rbErr, err)
+			}
+			return
+		}
+
+		if cErr := savepoint.Commit(ctx); cErr != nil {
+			err = fmt.Errorf("executor: release savepoint: %w", cErr)
+		}
+	}()
+
+	err = fn(spCtx)
+	return err
+}
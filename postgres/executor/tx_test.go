@@ -0,0 +1,177 @@
+package executor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// fakeTx is a minimal pgx.Tx whose Begin/Commit/Rollback behavior and call counts
+// are controllable from tests. Methods the transactional helpers under test never
+// call panic, so an unexpected call fails loudly instead of silently returning a
+// zero value.
+type fakeTx struct {
+	beginTx   *fakeTx
+	beginErr  error
+	commitErr error
+
+	commits   int
+	rollbacks int
+
+	// sendBatchResults, if set, is returned by SendBatch instead of panicking.
+	sendBatchResults pgx.BatchResults
+}
+
+func (f *fakeTx) Begin(context.Context) (pgx.Tx, error) {
+	if f.beginErr != nil {
+		return nil, f.beginErr
+	}
+	return f.beginTx, nil
+}
+
+func (f *fakeTx) Commit(context.Context) error {
+	f.commits++
+	return f.commitErr
+}
+
+func (f *fakeTx) Rollback(context.Context) error {
+	f.rollbacks++
+	return nil
+}
+
+func (f *fakeTx) CopyFrom(context.Context, pgx.Identifier, []string, pgx.CopyFromSource) (int64, error) {
+	panic("not implemented")
+}
+
+func (f *fakeTx) SendBatch(_ context.Context, b *pgx.Batch) pgx.BatchResults {
+	if f.sendBatchResults == nil {
+		panic("not implemented")
+	}
+	return f.sendBatchResults
+}
+
+func (f *fakeTx) LargeObjects() pgx.LargeObjects { panic("not implemented") }
+
+func (f *fakeTx) Prepare(context.Context, string, string) (*pgconn.StatementDescription, error) {
+	panic("not implemented")
+}
+
+func (f *fakeTx) Exec(context.Context, string, ...any) (pgconn.CommandTag, error) {
+	panic("not implemented")
+}
+
+func (f *fakeTx) Query(context.Context, string, ...any) (pgx.Rows, error) {
+	panic("not implemented")
+}
+
+func (f *fakeTx) QueryRow(context.Context, string, ...any) pgx.Row { panic("not implemented") }
+
+func (f *fakeTx) Conn() *pgx.Conn { return nil }
+
+func TestManager_runInTx_CommitsOnSuccess(t *testing.T) {
+	m := &Manager{}
+	tx := &fakeTx{}
+
+	err := m.runInTx(context.Background(), tx, func(context.Context) error {
+		return nil
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tx.commits != 1 {
+		t.Fatalf("commits = %d, want 1", tx.commits)
+	}
+	if tx.rollbacks != 0 {
+		t.Fatalf("rollbacks = %d, want 0", tx.rollbacks)
+	}
+}
+
+func TestManager_runInTx_RollsBackOnError(t *testing.T) {
+	m := &Manager{}
+	tx := &fakeTx{}
+	wantErr := errors.New("repository failure")
+
+	err := m.runInTx(context.Background(), tx, func(context.Context) error {
+		return wantErr
+	})
+
+	if !errors.Is(err, wantErr) {
+		t.Fatalf("err = %v, want to wrap %v", err, wantErr)
+	}
+	if tx.rollbacks != 1 {
+		t.Fatalf("rollbacks = %d, want 1", tx.rollbacks)
+	}
+	if tx.commits != 0 {
+		t.Fatalf("commits = %d, want 0", tx.commits)
+	}
+}
+
+func TestManager_runInTx_RollsBackAndRepanicsOnPanic(t *testing.T) {
+	m := &Manager{}
+	tx := &fakeTx{}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatal("expected panic to propagate")
+		}
+		if tx.rollbacks != 1 {
+			t.Fatalf("rollbacks = %d, want 1", tx.rollbacks)
+		}
+		if tx.commits != 0 {
+			t.Fatalf("commits = %d, want 0", tx.commits)
+		}
+	}()
+
+	_ = m.runInTx(context.Background(), tx, func(context.Context) error {
+		panic("boom")
+	})
+}
+
+func TestManager_runInTx_FlushesBatchInjectedInsideCallback(t *testing.T) {
+	m := &Manager{}
+	tx := &fakeTx{}
+	parent := &fakeBatchParent{}
+
+	var dst []int
+
+	err := m.runInTx(context.Background(), tx, func(callbackCtx context.Context) error {
+		// This mirrors the natural, documented way to use NewBatch/InjectBatch: the
+		// batch and its context are created locally inside the callback and never
+		// escape it.
+		parent.results = &fakeBatchResults{execTags: []pgconn.CommandTag{{}}}
+		batch := &BatchExecutor{Batch: &pgx.Batch{}, parent: parent}
+		batchCtx := m.InjectBatch(callbackCtx, batch)
+		return QueueExec(batchCtx, batch, nil, "INSERT INTO t VALUES ($1)", 1)
+	})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if tx.commits != 1 {
+		t.Fatalf("commits = %d, want 1", tx.commits)
+	}
+	if parent.sent == nil {
+		t.Fatal("expected the batch to be sent via the parent executor before commit")
+	}
+	if parent.sent.Len() != 1 {
+		t.Fatalf("flushed batch size = %d, want 1", parent.sent.Len())
+	}
+	_ = dst
+}
+
+// fakeBatchParent is the minimal Executor used as a BatchExecutor's parent in
+// tests: it only needs to support SendBatch.
+type fakeBatchParent struct {
+	Executor
+	sent    *pgx.Batch
+	results pgx.BatchResults
+}
+
+func (p *fakeBatchParent) SendBatch(_ context.Context, b *pgx.Batch) pgx.BatchResults {
+	p.sent = b
+	return p.results
+}
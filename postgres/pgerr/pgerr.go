@@ -0,0 +1,92 @@
+// Package pgerr translates *pgconn.PgError failures into typed sentinel errors so
+// callers can branch on the kind of constraint violation with errors.Is instead of
+// inspecting raw SQLSTATE codes.
+package pgerr
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// SQLSTATE codes this package recognizes.
+// See https://www.postgresql.org/docs/current/errcodes-appendix.html
+const (
+	codeUniqueViolation      = "23505"
+	codeForeignKeyViolation  = "23503"
+	codeCheckViolation       = "23514"
+	codeNotNullViolation     = "23502"
+	codeSerializationFailure = "40001"
+	codeDeadlockDetected     = "40P01"
+)
+
+var (
+	ErrUniqueViolation      = errors.New("pgerr: unique violation")
+	ErrForeignKeyViolation  = errors.New("pgerr: foreign key violation")
+	ErrCheckViolation       = errors.New("pgerr: check violation")
+	ErrNotNullViolation     = errors.New("pgerr: not-null violation")
+	ErrSerializationFailure = errors.New("pgerr: serialization failure")
+	ErrDeadlock             = errors.New("pgerr: deadlock detected")
+)
+
+var sentinelByCode = map[string]error{
+	codeUniqueViolation:      ErrUniqueViolation,
+	codeForeignKeyViolation:  ErrForeignKeyViolation,
+	codeCheckViolation:       ErrCheckViolation,
+	codeNotNullViolation:     ErrNotNullViolation,
+	codeSerializationFailure: ErrSerializationFailure,
+	codeDeadlockDetected:     ErrDeadlock,
+}
+
+// Error wraps a *pgconn.PgError with the matching sentinel above, so the error can
+// be matched with errors.Is(err, pgerr.ErrUniqueViolation) while still exposing the
+// original constraint/table/column diagnostics via errors.As.
+type Error struct {
+	Sentinel error
+	PgErr    *pgconn.PgError
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf(
+		"%s: SQLSTATE %s: %s (constraint=%q table=%q column=%q detail=%q hint=%q where=%q)",
+		e.Sentinel, e.PgErr.Code, e.PgErr.Message,
+		e.PgErr.ConstraintName, e.PgErr.TableName, e.PgErr.ColumnName,
+		e.PgErr.Detail, e.PgErr.Hint, e.PgErr.Where,
+	)
+}
+
+func (e *Error) Is(target error) bool { return errors.Is(e.Sentinel, target) }
+func (e *Error) Unwrap() error        { return e.PgErr }
+
+// Translate converts err into a typed *Error when it wraps a recognized
+// *pgconn.PgError. Unrecognized codes and non-Postgres errors are returned
+// unchanged so callers can still fall back to errors.Is/As on the original error.
+func Translate(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	var pgErr *pgconn.PgError
+	if !errors.As(err, &pgErr) {
+		return err
+	}
+
+	sentinel, ok := sentinelByCode[pgErr.Code]
+	if !ok {
+		return err
+	}
+
+	return &Error{Sentinel: sentinel, PgErr: pgErr}
+}
+
+// IsUniqueViolation reports whether err is, or wraps, a unique-constraint violation.
+func IsUniqueViolation(err error) bool { return errors.Is(err, ErrUniqueViolation) }
+
+// IsEntityExists is IsUniqueViolation phrased for callers that think in terms of
+// "does this entity already exist" rather than SQL constraint types.
+func IsEntityExists(err error) bool { return IsUniqueViolation(err) }
+
+// IsEmptyResponse reports whether err is, or wraps, pgx.ErrNoRows.
+func IsEmptyResponse(err error) bool { return errors.Is(err, pgx.ErrNoRows) }
@@ -0,0 +1,26 @@
+// Package postgres provides the connection pool used throughout this module's
+// Postgres integrations.
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// Pool wraps a pgxpool.Pool. It exists as its own type (rather than a bare
+// *pgxpool.Pool alias) so packages built on top of it, such as executor, have a
+// stable place to hang module-specific configuration without depending on pgxpool
+// directly.
+type Pool struct {
+	*pgxpool.Pool
+}
+
+// NewPool connects to Postgres using connString and wraps the resulting pool.
+func NewPool(ctx context.Context, connString string) (*Pool, error) {
+	pool, err := pgxpool.New(ctx, connString)
+	if err != nil {
+		return nil, err
+	}
+	return &Pool{Pool: pool}, nil
+}